@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/docker/docker/api/errors"
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
@@ -173,6 +174,13 @@ func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r
 		return err
 	}
 
+	format := r.Form.Get("format")
+	switch format {
+	case "", "docker", "oci":
+	default:
+		return errors.NewBadRequestError(fmt.Errorf("invalid format %q: must be one of \"docker\" or \"oci\"", format))
+	}
+
 	w.Header().Set("Content-Type", "application/x-tar")
 
 	output := ioutils.NewWriteFlusher(w)
@@ -184,7 +192,7 @@ func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r
 		names = r.Form["names"]
 	}
 
-	if err := s.backend.ExportImage(names, output); err != nil {
+	if err := s.backend.ExportImage(names, format, output); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -74,6 +74,7 @@ type ContainerLogsOptions struct {
 	ShowStdout bool
 	ShowStderr bool
 	Since      string
+	Until      string
 	Timestamps bool
 	Follow     bool
 	Tail       string
@@ -243,6 +244,13 @@ type ImageRemoveOptions struct {
 	PruneChildren bool
 }
 
+// ImageSaveOptions holds parameters to save images with.
+type ImageSaveOptions struct {
+	// Format is the format of the archive, either "docker" (the default)
+	// or "oci" for an OCI image layout.
+	Format string
+}
+
 // ImageSearchOptions holds parameters to search images with.
 type ImageSearchOptions struct {
 	RegistryAuth  string
@@ -4,15 +4,19 @@ import (
 	"io"
 	"net/url"
 
+	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
 )
 
 // ImageSave retrieves one or more images from the docker host as an io.ReadCloser.
 // It's up to the caller to store the images and close the stream.
-func (cli *Client) ImageSave(ctx context.Context, imageIDs []string) (io.ReadCloser, error) {
+func (cli *Client) ImageSave(ctx context.Context, imageIDs []string, options types.ImageSaveOptions) (io.ReadCloser, error) {
 	query := url.Values{
 		"names": imageIDs,
 	}
+	if options.Format != "" {
+		query.Set("format", options.Format)
+	}
 
 	resp, err := cli.get(ctx, "/images/get", query, nil)
 	if err != nil {
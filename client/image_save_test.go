@@ -11,13 +11,15 @@ import (
 	"golang.org/x/net/context"
 
 	"strings"
+
+	"github.com/docker/docker/api/types"
 )
 
 func TestImageSaveError(t *testing.T) {
 	client := &Client{
 		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
 	}
-	_, err := client.ImageSave(context.Background(), []string{"nothing"})
+	_, err := client.ImageSave(context.Background(), []string{"nothing"}, types.ImageSaveOptions{})
 	if err == nil || err.Error() != "Error response from daemon: Server error" {
 		t.Fatalf("expected a Server error, got %v", err)
 	}
@@ -36,6 +38,9 @@ func TestImageSave(t *testing.T) {
 			if !reflect.DeepEqual(names, expectedNames) {
 				return nil, fmt.Errorf("names not set in URL query properly. Expected %v, got %v", names, expectedNames)
 			}
+			if format := query.Get("format"); format != "oci" {
+				return nil, fmt.Errorf("format not set in URL query properly. Expected 'oci', got %s", format)
+			}
 
 			return &http.Response{
 				StatusCode: http.StatusOK,
@@ -43,7 +48,7 @@ func TestImageSave(t *testing.T) {
 			}, nil
 		}),
 	}
-	saveResponse, err := client.ImageSave(context.Background(), []string{"image_id1", "image_id2"})
+	saveResponse, err := client.ImageSave(context.Background(), []string{"image_id1", "image_id2"}, types.ImageSaveOptions{Format: "oci"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -24,6 +24,8 @@ import (
 	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/logger/jsonfilelog"
+	"github.com/docker/docker/daemon/logger/local"
+	"github.com/docker/docker/daemon/logger/loggerutils/cache"
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
@@ -338,11 +340,35 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 		}
 	}
 
+	// Set logging file for the "local" driver
+	if cfg.Type == local.Name {
+		info.LogPath, err = container.GetRootResourcePath(filepath.Join("local-logs", "container.log"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	l, err := initDriver(info)
 	if err != nil {
 		return nil, err
 	}
 
+	// Keep a local copy of the logs for drivers that cannot read them back,
+	// so that `docker logs` keeps working.
+	if _, ok := l.(logger.LogReader); !ok && cache.ShouldUseCache(cfg.Config) {
+		info.LogPath, err = container.GetRootResourcePath("container-cached.log")
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		cl, err := cache.WithLocalCache(l, info)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		l = cl
+	}
+
 	if containertypes.LogMode(cfg.Config["mode"]) == containertypes.LogModeNonBlock {
 		bufferSize := int64(-1)
 		if s, exists := cfg.Config["max-buffer-size"]; exists {
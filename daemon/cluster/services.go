@@ -391,7 +391,27 @@ func (c *Cluster) ServiceLogs(ctx context.Context, selector *backend.LogSelector
 		}
 	}
 
-	stream, err := state.logsClient.SubscribeLogs(ctx, &swarmapi.SubscribeLogsRequest{
+	// get the until value - swarmkit has no notion of it, so messages past
+	// the bound are dropped here, and following stops once it has passed
+	var until time.Time
+	streamCtx := ctx
+	if config.Until != "" && config.Until != "0" {
+		s, n, err := timetypes.ParseTimestamps(config.Until, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse until timestamp")
+		}
+		until = time.Unix(s, n)
+		if config.Follow {
+			var cancel context.CancelFunc
+			streamCtx, cancel = context.WithDeadline(ctx, until)
+			go func() {
+				<-streamCtx.Done()
+				cancel()
+			}()
+		}
+	}
+
+	stream, err := state.logsClient.SubscribeLogs(streamCtx, &swarmapi.SubscribeLogsRequest{
 		Selector: swarmSelector,
 		Options: &swarmapi.LogSubscriptionOptions{
 			Follow:  config.Follow,
@@ -418,6 +438,10 @@ func (c *Cluster) ServiceLogs(ctx context.Context, selector *backend.LogSelector
 			if err == io.EOF {
 				return
 			}
+			// the until bound was reached while following
+			if err != nil && streamCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				return
+			}
 			// if we're not io.EOF, push the message in and return
 			if err != nil {
 				select {
@@ -455,6 +479,10 @@ func (c *Cluster) ServiceLogs(ctx context.Context, selector *backend.LogSelector
 				}
 				m.Line = msg.Data
 
+				if !until.IsZero() && m.Timestamp.After(until) {
+					continue
+				}
+
 				// there could be a case where the reader stops accepting
 				// messages and the context is canceled. we need to check that
 				// here, or otherwise we risk blocking forever on the message
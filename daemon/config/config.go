@@ -31,6 +31,9 @@ const (
 	// maximum number of uploads that
 	// may take place at a time for each push.
 	DefaultMaxConcurrentUploads = 5
+	// DefaultPushCompression is the default compression algorithm used
+	// for layers that are pushed to a registry.
+	DefaultPushCompression = "gzip"
 	// StockRuntimeName is the reserved name/alias used to represent the
 	// OCI runtime being shipped with the docker daemon package.
 	StockRuntimeName = "runc"
@@ -137,6 +140,10 @@ type CommonConfig struct {
 	// may take place at a time for each push.
 	MaxConcurrentUploads *int `json:"max-concurrent-uploads,omitempty"`
 
+	// PushCompression is the compression algorithm ("gzip" or "zstd")
+	// used for layers that are pushed to a registry.
+	PushCompression string `json:"push-compression,omitempty"`
+
 	// ShutdownTimeout is the timeout value (in seconds) the daemon will wait for the container
 	// to stop when daemon is being shutdown
 	ShutdownTimeout int `json:"shutdown-timeout,omitempty"`
@@ -489,6 +496,12 @@ func Validate(config *Config) error {
 	if config.MaxConcurrentUploads != nil && *config.MaxConcurrentUploads < 0 {
 		return fmt.Errorf("invalid max concurrent uploads: %d", *config.MaxConcurrentUploads)
 	}
+	// validate PushCompression
+	switch config.PushCompression {
+	case "", "gzip", "zstd":
+	default:
+		return fmt.Errorf("invalid push compression: %s", config.PushCompression)
+	}
 
 	// validate that "default" runtime is not reset
 	if runtimes := config.GetAllRuntimes(); len(runtimes) > 0 {
@@ -8,6 +8,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/distribution"
 	progressutils "github.com/docker/docker/distribution/utils"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"golang.org/x/net/context"
 )
@@ -39,6 +40,11 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 		close(writesDone)
 	}()
 
+	layerCompression := archive.Gzip
+	if daemon.configStore.PushCompression == "zstd" {
+		layerCompression = archive.Zstd
+	}
+
 	imagePushConfig := &distribution.ImagePushConfig{
 		Config: distribution.Config{
 			MetaHeaders:      metaHeaders,
@@ -50,10 +56,11 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 			ImageStore:       distribution.NewImageConfigStoreFromStore(daemon.imageStore),
 			ReferenceStore:   daemon.referenceStore,
 		},
-		ConfigMediaType: schema2.MediaTypeImageConfig,
-		LayerStore:      distribution.NewLayerProviderFromStore(daemon.layerStore),
-		TrustKey:        daemon.trustKey,
-		UploadManager:   daemon.uploadManager,
+		ConfigMediaType:  schema2.MediaTypeImageConfig,
+		LayerStore:       distribution.NewLayerProviderFromStore(daemon.layerStore),
+		TrustKey:         daemon.trustKey,
+		UploadManager:    daemon.uploadManager,
+		LayerCompression: layerCompression,
 	}
 
 	err = distribution.Push(ctx, ref, imagePushConfig)
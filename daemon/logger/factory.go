@@ -121,6 +121,26 @@ var builtInLogOpts = map[string]bool{
 	"max-buffer-size": true,
 }
 
+var externalValidators []LogOptValidator
+
+// AddBuiltinLogOpts updates the list of built-in log opts. This allows other
+// packages to supplement additional log options without having to register a
+// logging driver. The log opts are not passed on to the driver's validator.
+// This should only be called on package initialization.
+func AddBuiltinLogOpts(opts map[string]bool) {
+	for k, v := range opts {
+		builtInLogOpts[k] = v
+	}
+}
+
+// RegisterExternalValidator adds the validator to the list of validators that
+// are run on the options of every logging driver. This allows other packages
+// to validate the built-in log opts they added with AddBuiltinLogOpts.
+// This should only be called on package initialization.
+func RegisterExternalValidator(v LogOptValidator) {
+	externalValidators = append(externalValidators, v)
+}
+
 // ValidateLogOpts checks the options for the given log driver. The
 // options supported are specific to the LogDriver implementation.
 func ValidateLogOpts(name string, cfg map[string]string) error {
@@ -147,6 +167,12 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 		return fmt.Errorf("logger: no log driver named '%s' is registered", name)
 	}
 
+	for _, v := range externalValidators {
+		if err := v(cfg); err != nil {
+			return err
+		}
+	}
+
 	filteredOpts := make(map[string]string, len(builtInLogOpts))
 	for k, v := range cfg {
 		if !builtInLogOpts[k] {
@@ -170,11 +170,18 @@ func (s *journald) Close() error {
 	return nil
 }
 
-func (s *journald) drainJournal(logWatcher *logger.LogWatcher, config logger.ReadConfig, j *C.sd_journal, oldCursor *C.char) *C.char {
+func (s *journald) drainJournal(logWatcher *logger.LogWatcher, config logger.ReadConfig, j *C.sd_journal, oldCursor *C.char) (*C.char, bool) {
 	var msg, data, cursor *C.char
 	var length C.size_t
 	var stamp C.uint64_t
 	var priority, partial C.int
+	var done bool
+	var untilUnixMicro uint64
+
+	// If we have an until time, convert it to Unix time once.
+	if !config.Until.IsZero() {
+		untilUnixMicro = uint64(config.Until.UnixNano() / 1000)
+	}
 
 	// Walk the journal from here forward until we run out of new entries.
 drain:
@@ -194,6 +201,11 @@ drain:
 			if C.sd_journal_get_realtime_usec(j, &stamp) != 0 {
 				break
 			}
+			// Break if the timestamp exceeds the until bound.
+			if untilUnixMicro != 0 && untilUnixMicro < uint64(stamp) {
+				done = true
+				break
+			}
 			// Set up the time and text of the entry.
 			timestamp := time.Unix(int64(stamp)/1000000, (int64(stamp)%1000000)*1000)
 			line := C.GoBytes(unsafe.Pointer(msg), C.int(length))
@@ -242,7 +254,7 @@ drain:
 		// ensure that we won't be freeing an address that's invalid
 		cursor = nil
 	}
-	return cursor
+	return cursor, done
 }
 
 func (s *journald) followJournal(logWatcher *logger.LogWatcher, config logger.ReadConfig, j *C.sd_journal, pfd [2]C.int, cursor *C.char) *C.char {
@@ -259,7 +271,16 @@ func (s *journald) followJournal(logWatcher *logger.LogWatcher, config logger.Re
 
 	newCursor := make(chan *C.char)
 
+	// stop following once the until bound has passed
+	var untilC <-chan time.Time
+	if !config.Until.IsZero() {
+		untilTimer := time.NewTimer(config.Until.Sub(time.Now()))
+		defer untilTimer.Stop()
+		untilC = untilTimer.C
+	}
+
 	go func() {
+		var done bool
 		for {
 			// Keep copying journal data out until we're notified to stop
 			// or we hit an error.
@@ -272,9 +293,9 @@ func (s *journald) followJournal(logWatcher *logger.LogWatcher, config logger.Re
 				break
 			}
 
-			cursor = s.drainJournal(logWatcher, config, j, cursor)
+			cursor, done = s.drainJournal(logWatcher, config, j, cursor)
 
-			if status != 1 {
+			if status != 1 || done {
 				// We were notified to stop
 				break
 			}
@@ -296,6 +317,11 @@ func (s *journald) followJournal(logWatcher *logger.LogWatcher, config logger.Re
 		// Notify the other goroutine that its work is done.
 		C.close(pfd[1])
 		cursor = <-newCursor
+	case <-untilC:
+		// The until bound has passed, one last drain happens once
+		// the other goroutine is notified.
+		C.close(pfd[1])
+		cursor = <-newCursor
 	}
 
 	return cursor
@@ -347,8 +373,15 @@ func (s *journald) readLogs(logWatcher *logger.LogWatcher, config logger.ReadCon
 	}
 	if config.Tail > 0 {
 		lines := config.Tail
-		// Start at the end of the journal.
-		if C.sd_journal_seek_tail(j) < 0 {
+		// If we have an until time, start from there.
+		// Otherwise, start at the end of the journal.
+		if !config.Until.IsZero() {
+			untilUnixMicro := uint64(config.Until.UnixNano() / 1000)
+			if C.sd_journal_seek_realtime_usec(j, C.uint64_t(untilUnixMicro)) < 0 {
+				logWatcher.Err <- fmt.Errorf("error seeking to end time in journal")
+				return
+			}
+		} else if C.sd_journal_seek_tail(j) < 0 {
 			logWatcher.Err <- fmt.Errorf("error seeking to end of journal")
 			return
 		}
@@ -394,8 +427,9 @@ func (s *journald) readLogs(logWatcher *logger.LogWatcher, config logger.ReadCon
 			return
 		}
 	}
-	cursor = s.drainJournal(logWatcher, config, j, nil)
-	if config.Follow {
+	var done bool
+	cursor, done = s.drainJournal(logWatcher, config, j, nil)
+	if config.Follow && !done {
 		// Allocate a descriptor for following the journal, if we'll
 		// need one.  Do it here so that we can report if it fails.
 		if fd := C.sd_journal_get_fd(j); fd < C.int(0) {
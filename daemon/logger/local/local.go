@@ -0,0 +1,221 @@
+// Package local provides a logger implementation that stores logs on disk
+// in a compact, length-prefixed binary format. Rotated files are compressed
+// by default.
+package local
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/plugins/logdriver"
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/loggerutils"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Name is the name of the driver
+	Name = "local"
+
+	encodeBinaryLen = 4
+	initialBufSize  = 2048
+
+	defaultMaxFileSize  int64 = 20 * 1024 * 1024
+	defaultMaxFileCount       = 5
+	defaultCompressLogs       = true
+)
+
+// LogOptKeys are the keys names used for log opts passed in to initialize the driver.
+var LogOptKeys = map[string]bool{
+	"max-file": true,
+	"max-size": true,
+	"compress": true,
+}
+
+// ValidateLogOpt looks for log driver specific options.
+func ValidateLogOpt(cfg map[string]string) error {
+	for key := range cfg {
+		if !LogOptKeys[key] {
+			return errors.Errorf("unknown log opt '%s' for log driver %s", key, Name)
+		}
+	}
+	_, err := parseConfig(cfg)
+	return err
+}
+
+func init() {
+	if err := logger.RegisterLogDriver(Name, New); err != nil {
+		logrus.Fatal(err)
+	}
+	if err := logger.RegisterLogOptValidator(Name, ValidateLogOpt); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+// CreateConfig is used to configure new instances of driver
+type CreateConfig struct {
+	MaxFileSize  int64
+	MaxFileCount int
+	Compress     bool
+}
+
+func parseConfig(cfg map[string]string) (*CreateConfig, error) {
+	c := &CreateConfig{
+		MaxFileSize:  defaultMaxFileSize,
+		MaxFileCount: defaultMaxFileCount,
+		Compress:     defaultCompressLogs,
+	}
+
+	if s, ok := cfg["max-size"]; ok {
+		var err error
+		c.MaxFileSize, err = units.FromHumanSize(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing max-size")
+		}
+		if c.MaxFileSize < 0 {
+			return nil, errors.New("max-size cannot be less than 0")
+		}
+	}
+
+	if s, ok := cfg["max-file"]; ok {
+		var err error
+		c.MaxFileCount, err = strconv.Atoi(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing max-file")
+		}
+		if c.MaxFileCount < 1 {
+			return nil, errors.New("max-file cannot be less than 1")
+		}
+	}
+
+	if s, ok := cfg["compress"]; ok {
+		var err error
+		c.Compress, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing compress")
+		}
+	}
+
+	if c.Compress && c.MaxFileCount == 1 {
+		return nil, errors.New("compress cannot be true when max-file is less than 2")
+	}
+	return c, nil
+}
+
+// driver is the Logger implementation for the local log driver.
+type driver struct {
+	mu      sync.Mutex
+	closed  bool
+	writer  *loggerutils.RotateFileWriter
+	readers map[*logger.LogWatcher]struct{} // stores the active log followers
+
+	buf   []byte
+	proto *logdriver.LogEntry
+}
+
+// New creates a new local logger
+// You must provide the `LogPath` in the passed in info argument, this is the
+// file path that logs are written to.
+func New(info logger.Info) (logger.Logger, error) {
+	if info.LogPath == "" {
+		return nil, errors.New("log path is missing -- this is a bug and should not happen")
+	}
+
+	cfg, err := parseConfig(info.Config)
+	if err != nil {
+		return nil, err
+	}
+	return newDriver(info.LogPath, cfg)
+}
+
+func newDriver(logPath string, cfg *CreateConfig) (logger.Logger, error) {
+	if err := os.MkdirAll(filepath.Dir(logPath), 0700); err != nil {
+		return nil, errors.Wrap(err, "error setting up log directory")
+	}
+
+	capacity := cfg.MaxFileSize
+	if capacity == 0 {
+		capacity = -1
+	}
+	writer, err := loggerutils.NewRotateFileWriter(logPath, capacity, cfg.MaxFileCount, cfg.Compress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &driver{
+		writer:  writer,
+		readers: make(map[*logger.LogWatcher]struct{}),
+		buf:     make([]byte, initialBufSize),
+		proto:   &logdriver.LogEntry{},
+	}, nil
+}
+
+func (d *driver) Name() string {
+	return Name
+}
+
+// Log encodes the message and writes it to the underlying file.
+//
+// Each entry is stored as:
+//
+// [uint32 size][protobuf LogEntry][uint32 size]
+//
+// The trailing size makes it possible to walk the file backwards, which is
+// used to read the tail of the logs without decoding everything before it.
+func (d *driver) Log(msg *logger.Message) error {
+	d.mu.Lock()
+	err := d.writeLogEntry(msg)
+	d.mu.Unlock()
+	logger.PutMessage(msg)
+	return err
+}
+
+func (d *driver) writeLogEntry(msg *logger.Message) error {
+	if d.closed {
+		return errors.New("logger is closed")
+	}
+
+	d.proto.Reset()
+	d.proto.Source = msg.Source
+	d.proto.TimeNano = msg.Timestamp.UnixNano()
+	d.proto.Line = msg.Line
+	d.proto.Partial = msg.Partial
+
+	size := d.proto.Size()
+	total := size + 2*encodeBinaryLen
+	if total > len(d.buf) {
+		d.buf = make([]byte, total)
+	}
+
+	binary.BigEndian.PutUint32(d.buf[:encodeBinaryLen], uint32(size))
+	n, err := d.proto.MarshalTo(d.buf[encodeBinaryLen : size+encodeBinaryLen])
+	if err != nil {
+		return errors.Wrap(err, "error marshaling log entry")
+	}
+	if n != size {
+		return fmt.Errorf("marshaled log entry has unexpected size: %d != %d", n, size)
+	}
+	binary.BigEndian.PutUint32(d.buf[size+encodeBinaryLen:total], uint32(size))
+
+	_, err = d.writer.Write(d.buf[:total])
+	return err
+}
+
+// Close closes underlying file and signals all readers to stop.
+func (d *driver) Close() error {
+	d.mu.Lock()
+	d.closed = true
+	err := d.writer.Close()
+	for r := range d.readers {
+		r.Close()
+		delete(d.readers, r)
+	}
+	d.mu.Unlock()
+	return err
+}
@@ -0,0 +1,180 @@
+package local
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/daemon/logger"
+)
+
+func newTestLogger(t *testing.T, cfg map[string]string) (*driver, func()) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := New(logger.Info{
+		LogPath: filepath.Join(dir, "local-logs", "container.log"),
+		Config:  cfg,
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return l.(*driver), func() {
+		l.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func readAll(t *testing.T, l logger.LogReader, cfg logger.ReadConfig) []*logger.Message {
+	watcher := l.ReadLogs(cfg)
+	defer watcher.Close()
+
+	var msgs []*logger.Message
+	for {
+		select {
+		case msg, ok := <-watcher.Msg:
+			if !ok {
+				return msgs
+			}
+			msgs = append(msgs, msg)
+		case err := <-watcher.Err:
+			t.Fatal(err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for logs")
+		}
+	}
+}
+
+func TestLocalWriteRead(t *testing.T) {
+	l, cleanup := newTestLogger(t, nil)
+	defer cleanup()
+
+	ts := time.Now()
+	for i := 0; i < 3; i++ {
+		msg := logger.NewMessage()
+		msg.Source = "stdout"
+		msg.Timestamp = ts.Add(time.Duration(i) * time.Second)
+		msg.Line = append(msg.Line, fmt.Sprintf("line%d", i)...)
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msgs := readAll(t, l, logger.ReadConfig{Tail: -1})
+	if len(msgs) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(msgs))
+	}
+	for i, msg := range msgs {
+		if expected := fmt.Sprintf("line%d", i); string(msg.Line) != expected {
+			t.Fatalf("expected %q, got %q", expected, msg.Line)
+		}
+		if msg.Source != "stdout" {
+			t.Fatalf("expected source stdout, got %q", msg.Source)
+		}
+		if !msg.Timestamp.Equal(ts.Add(time.Duration(i) * time.Second)) {
+			t.Fatalf("unexpected timestamp %v", msg.Timestamp)
+		}
+	}
+
+	msgs = readAll(t, l, logger.ReadConfig{Tail: 1})
+	if len(msgs) != 1 || string(msgs[0].Line) != "line2" {
+		t.Fatalf("unexpected tail result: %v", msgs)
+	}
+
+	msgs = readAll(t, l, logger.ReadConfig{Tail: -1, Since: ts.Add(time.Second)})
+	if len(msgs) != 2 || string(msgs[0].Line) != "line1" {
+		t.Fatalf("unexpected since result: %v", msgs)
+	}
+
+	msgs = readAll(t, l, logger.ReadConfig{Tail: -1, Until: ts.Add(time.Second)})
+	if len(msgs) != 2 || string(msgs[1].Line) != "line1" {
+		t.Fatalf("unexpected until result: %v", msgs)
+	}
+
+	msgs = readAll(t, l, logger.ReadConfig{Tail: -1, Follow: true, Until: ts.Add(time.Second)})
+	if len(msgs) != 2 {
+		t.Fatalf("unexpected until result while following: %v", msgs)
+	}
+}
+
+func TestLocalRotateCompressed(t *testing.T) {
+	l, cleanup := newTestLogger(t, map[string]string{"max-size": "100", "max-file": "3"})
+	defer cleanup()
+
+	for i := 0; i < 20; i++ {
+		msg := logger.NewMessage()
+		msg.Source = "stderr"
+		msg.Timestamp = time.Now()
+		msg.Line = append(msg.Line, fmt.Sprintf("line%02d", i)...)
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logPath := l.writer.LogPath()
+	for _, name := range []string{logPath + ".1.gz", logPath + ".2.gz"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Fatalf("expected compressed rotated file: %v", err)
+		}
+	}
+
+	msgs := readAll(t, l, logger.ReadConfig{Tail: -1})
+	if len(msgs) == 0 || string(msgs[len(msgs)-1].Line) != "line19" {
+		t.Fatalf("unexpected messages: %v", msgs)
+	}
+	for i := 1; i < len(msgs); i++ {
+		if string(msgs[i-1].Line) >= string(msgs[i].Line) {
+			t.Fatalf("messages out of order: %q before %q", msgs[i-1].Line, msgs[i].Line)
+		}
+	}
+
+	msgs = readAll(t, l, logger.ReadConfig{Tail: 5})
+	if len(msgs) != 5 || string(msgs[0].Line) != "line15" {
+		t.Fatalf("unexpected tail result: %v", msgs)
+	}
+}
+
+func TestLocalFollow(t *testing.T) {
+	l, cleanup := newTestLogger(t, map[string]string{"max-size": "100", "max-file": "2"})
+	defer cleanup()
+
+	watcher := l.ReadLogs(logger.ReadConfig{Tail: -1, Follow: true})
+	defer watcher.Close()
+
+	for i := 0; i < 10; i++ {
+		msg := logger.NewMessage()
+		msg.Timestamp = time.Now()
+		msg.Line = append(msg.Line, fmt.Sprintf("line%d", i)...)
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case msg := <-watcher.Msg:
+			if expected := fmt.Sprintf("line%d", i); string(msg.Line) != expected {
+				t.Fatalf("expected %q, got %q", expected, msg.Line)
+			}
+		case err := <-watcher.Err:
+			t.Fatal(err)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timeout waiting for followed log message %d", i)
+		}
+	}
+}
+
+func TestValidateLogOpt(t *testing.T) {
+	if err := ValidateLogOpt(map[string]string{"max-size": "10m", "max-file": "3", "compress": "false"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateLogOpt(map[string]string{"labels": "foo"}); err == nil {
+		t.Fatal("expected error for unknown log opt")
+	}
+	if err := ValidateLogOpt(map[string]string{"max-file": "1"}); err == nil {
+		t.Fatal("expected error for compress with a single file")
+	}
+}
@@ -0,0 +1,492 @@
+package local
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/net/context"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/plugins/logdriver"
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/pkg/filenotify"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/pkg/errors"
+)
+
+const maxDecodeRetry = 20000
+
+var (
+	errRetry = errors.New("retry")
+	errDone  = errors.New("done")
+)
+
+// ReadLogs implements the logger's LogReader interface for the logs
+// created by this driver.
+func (d *driver) ReadLogs(config logger.ReadConfig) *logger.LogWatcher {
+	logWatcher := logger.NewLogWatcher()
+
+	go d.readLogs(logWatcher, config)
+	return logWatcher
+}
+
+func (d *driver) readLogs(logWatcher *logger.LogWatcher, config logger.ReadConfig) {
+	defer close(logWatcher.Msg)
+
+	// lock so the read stream doesn't get corrupted due to rotations or other log data written while we read
+	// This will block writes!!!
+	d.mu.Lock()
+
+	pth := d.writer.LogPath()
+	var files []io.ReadSeeker
+	closeFiles := func() {
+		for _, f := range files {
+			if err := f.(io.Closer).Close(); err != nil {
+				logrus.WithField("logger", Name).Warnf("error closing tailed log file: %v", err)
+			}
+		}
+	}
+
+	for i := d.writer.MaxFiles(); i > 1; i-- {
+		f, err := openRotatedFile(fmt.Sprintf("%s.%d", pth, i-1))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logWatcher.Err <- err
+				break
+			}
+			continue
+		}
+		files = append(files, f)
+	}
+
+	latestFile, err := os.Open(pth)
+	if err != nil {
+		closeFiles()
+		logWatcher.Err <- err
+		d.mu.Unlock()
+		return
+	}
+
+	if config.Tail != 0 {
+		tailer := ioutils.MultiReadSeeker(append(files, latestFile)...)
+		tailFile(tailer, logWatcher, config.Tail, config.Since, config.Until)
+	}
+
+	// close all the rotated files
+	closeFiles()
+
+	if !config.Follow || d.closed {
+		latestFile.Close()
+		d.mu.Unlock()
+		return
+	}
+
+	if config.Tail >= 0 {
+		latestFile.Seek(0, os.SEEK_END)
+	}
+
+	notifyRotate := d.writer.NotifyRotate()
+	defer d.writer.NotifyRotateEvict(notifyRotate)
+
+	d.readers[logWatcher] = struct{}{}
+
+	d.mu.Unlock()
+
+	followLogs(latestFile, logWatcher, notifyRotate, config.Since, config.Until)
+
+	d.mu.Lock()
+	delete(d.readers, logWatcher)
+	d.mu.Unlock()
+}
+
+// decompressedFile is a temporary, uncompressed copy of a rotated log file.
+// The copy is removed when the file is closed.
+type decompressedFile struct {
+	*os.File
+}
+
+func (f *decompressedFile) Close() error {
+	err := f.File.Close()
+	if rmErr := os.Remove(f.Name()); rmErr != nil && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// openRotatedFile opens the rotated log file with the given name. If a
+// compressed version of the file exists, it is decompressed to a temporary
+// file so that it can be seeked through like a regular log file.
+func openRotatedFile(name string) (io.ReadSeeker, error) {
+	cf, err := os.Open(name + ".gz")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		return os.Open(name)
+	}
+	defer cf.Close()
+
+	rdr, err := gzip.NewReader(cf)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading compressed log file %s", cf.Name())
+	}
+	defer rdr.Close()
+
+	tmp, err := ioutil.TempFile("", "docker-local-log-")
+	if err != nil {
+		return nil, err
+	}
+	f := &decompressedFile{tmp}
+	if _, err := io.Copy(f, rdr); err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "error decompressing log file %s", cf.Name())
+	}
+	if _, err := f.Seek(0, os.SEEK_SET); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// decoder reads log entries encoded by driver.Log from a stream.
+type decoder struct {
+	rdr   io.Reader
+	proto *logdriver.LogEntry
+	buf   []byte
+}
+
+func newDecoder(rdr io.Reader) *decoder {
+	return &decoder{
+		rdr:   rdr,
+		proto: &logdriver.LogEntry{},
+		buf:   make([]byte, initialBufSize),
+	}
+}
+
+// Decode reads the next entry from the stream.
+// io.EOF is returned when there is no more data to read, and
+// io.ErrUnexpectedEOF is returned if the stream ends in the middle of an
+// entry.
+func (d *decoder) Decode() (*logger.Message, error) {
+	if _, err := io.ReadFull(d.rdr, d.buf[:encodeBinaryLen]); err != nil {
+		return nil, err
+	}
+
+	size := int(binary.BigEndian.Uint32(d.buf[:encodeBinaryLen]))
+	total := size + encodeBinaryLen
+	if total > len(d.buf) {
+		d.buf = make([]byte, total)
+	}
+	if _, err := io.ReadFull(d.rdr, d.buf[:total]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	if footer := int(binary.BigEndian.Uint32(d.buf[size:total])); footer != size {
+		return nil, fmt.Errorf("log entry is corrupted: header size %d does not match footer size %d", size, footer)
+	}
+
+	d.proto.Reset()
+	if err := d.proto.Unmarshal(d.buf[:size]); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling log entry")
+	}
+
+	return &logger.Message{
+		Source:    d.proto.Source,
+		Timestamp: time.Unix(0, d.proto.TimeNano),
+		Line:      d.proto.Line,
+		Partial:   d.proto.Partial,
+	}, nil
+}
+
+// seekToTail moves the read offset of f to the beginning of the n-th entry
+// counting from the end of the stream, or to the start of the stream if it
+// holds less than n entries.
+func seekToTail(f io.ReadSeeker, n int) error {
+	offset, err := f.Seek(0, os.SEEK_END)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, encodeBinaryLen)
+	for i := 0; i < n && offset > 0; i++ {
+		if offset < 2*encodeBinaryLen {
+			return errors.New("log file is corrupted: truncated log entry")
+		}
+		if _, err := f.Seek(offset-encodeBinaryLen, os.SEEK_SET); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(f, buf); err != nil {
+			return err
+		}
+		offset -= int64(binary.BigEndian.Uint32(buf)) + 2*encodeBinaryLen
+		if offset < 0 {
+			return errors.New("log file is corrupted: invalid log entry size")
+		}
+	}
+
+	_, err = f.Seek(offset, os.SEEK_SET)
+	return err
+}
+
+func tailFile(f io.ReadSeeker, logWatcher *logger.LogWatcher, tail int, since, until time.Time) {
+	if tail > 0 {
+		if err := seekToTail(f, tail); err != nil {
+			logWatcher.Err <- err
+			return
+		}
+	} else if _, err := f.Seek(0, os.SEEK_SET); err != nil {
+		logWatcher.Err <- err
+		return
+	}
+
+	dec := newDecoder(f)
+	for {
+		msg, err := dec.Decode()
+		if err != nil {
+			if err != io.EOF {
+				logWatcher.Err <- err
+			}
+			return
+		}
+		if !since.IsZero() && msg.Timestamp.Before(since) {
+			continue
+		}
+		if !until.IsZero() && msg.Timestamp.After(until) {
+			return
+		}
+		select {
+		case <-logWatcher.WatchClose():
+			return
+		case logWatcher.Msg <- msg:
+		}
+	}
+}
+
+func watchFile(name string) (filenotify.FileWatcher, error) {
+	fileWatcher, err := filenotify.New()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fileWatcher.Add(name); err != nil {
+		logrus.WithField("logger", Name).Warnf("falling back to file poller due to error: %v", err)
+		fileWatcher.Close()
+		fileWatcher = filenotify.NewPollingWatcher()
+
+		if err := fileWatcher.Add(name); err != nil {
+			fileWatcher.Close()
+			logrus.Debugf("error watching log file for modifications: %v", err)
+			return nil, err
+		}
+	}
+	return fileWatcher, nil
+}
+
+func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan interface{}, since, until time.Time) {
+	dec := newDecoder(f)
+
+	name := f.Name()
+	fileWatcher, err := watchFile(name)
+	if err != nil {
+		logWatcher.Err <- err
+		return
+	}
+	defer func() {
+		f.Close()
+		fileWatcher.Remove(name)
+		fileWatcher.Close()
+	}()
+
+	// stop following once the until bound has passed
+	var untilC <-chan time.Time
+	if !until.IsZero() {
+		untilTimer := time.NewTimer(until.Sub(time.Now()))
+		defer untilTimer.Stop()
+		untilC = untilTimer.C
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-logWatcher.WatchClose():
+			fileWatcher.Remove(name)
+			cancel()
+		case <-untilC:
+			cancel()
+		case <-ctx.Done():
+			return
+		}
+	}()
+
+	// send forwards msg to the watcher unless it is filtered out by since.
+	// It returns errDone if reading should stop.
+	send := func(msg *logger.Message) error {
+		if !since.IsZero() && msg.Timestamp.Before(since) {
+			return nil
+		}
+		if !until.IsZero() && msg.Timestamp.After(until) {
+			return errDone
+		}
+		select {
+		case logWatcher.Msg <- msg:
+			return nil
+		case <-ctx.Done():
+			// make sure the message we consumed is sent
+			logWatcher.Msg <- msg
+			return errDone
+		}
+	}
+
+	var retries int
+	handleRotate := func() error {
+		// The rotated file is still readable through the open handle, so
+		// make sure entries written right before the rotation are not lost.
+		for {
+			msg, err := dec.Decode()
+			if err != nil {
+				break
+			}
+			if err := send(msg); err != nil {
+				return err
+			}
+		}
+
+		f.Close()
+		fileWatcher.Remove(name)
+
+		// retry when the file doesn't exist
+		for retries := 0; retries <= 5; retries++ {
+			f, err = os.Open(name)
+			if err == nil || !os.IsNotExist(err) {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err := fileWatcher.Add(name); err != nil {
+			return err
+		}
+		dec = newDecoder(f)
+		return nil
+	}
+
+	waitRead := func() error {
+		select {
+		case e := <-fileWatcher.Events():
+			switch e.Op {
+			case fsnotify.Write:
+				return nil
+			case fsnotify.Rename, fsnotify.Remove:
+				// Compressing a rotated file removes it after it was
+				// renamed, so events for the old file can still be queued
+				// after we switched over to the new one.
+				if fi, err := os.Stat(name); err == nil {
+					if cur, err := f.Stat(); err == nil && os.SameFile(fi, cur) {
+						return nil
+					}
+				}
+				select {
+				case <-notifyRotate:
+				case <-ctx.Done():
+					return errDone
+				}
+				return handleRotate()
+			}
+			return errRetry
+		case err := <-fileWatcher.Errors():
+			logrus.Debugf("logger got error watching file: %v", err)
+			// Something happened, let's try and stay alive and create a new watcher
+			if retries <= 5 {
+				fileWatcher.Close()
+				fileWatcher, err = watchFile(name)
+				if err != nil {
+					return err
+				}
+				retries++
+				return errRetry
+			}
+			return err
+		case <-ctx.Done():
+			return errDone
+		}
+	}
+
+	handleDecodeErr := func(err error, offset int64) error {
+		if err == io.ErrUnexpectedEOF && retries <= maxDecodeRetry {
+			// The entry is still being written, rewind to its start and
+			// wait for the rest of it.
+			if _, err := f.Seek(offset, os.SEEK_SET); err != nil {
+				return err
+			}
+			retries++
+			err = io.EOF
+		}
+		if err != io.EOF {
+			return err
+		}
+		for {
+			err := waitRead()
+			if err == nil {
+				return nil
+			}
+			if err != errRetry {
+				return err
+			}
+		}
+	}
+
+	// main loop
+	for {
+		offset, err := f.Seek(0, os.SEEK_CUR)
+		if err != nil {
+			logWatcher.Err <- err
+			return
+		}
+		msg, err := dec.Decode()
+		if err != nil {
+			if err := handleDecodeErr(err, offset); err != nil {
+				if err == errDone {
+					return
+				}
+				// we got an unrecoverable error, so return
+				logWatcher.Err <- err
+				return
+			}
+			// ready to try again
+			continue
+		}
+
+		retries = 0 // reset retries since we've succeeded
+		if err := send(msg); err != nil {
+			if ctx.Err() == nil {
+				// the until bound was passed
+				return
+			}
+			// the watcher was closed, drain whatever is left in the file
+			for {
+				msg, err := dec.Decode()
+				if err != nil {
+					return
+				}
+				if !since.IsZero() && msg.Timestamp.Before(since) {
+					continue
+				}
+				if !until.IsZero() && msg.Timestamp.After(until) {
+					return
+				}
+				logWatcher.Msg <- msg
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package cache provides a logger that keeps a bounded, local copy of
+// everything written to another logger, so that logs can be read back even
+// when the wrapped logging driver does not support reading.
+package cache
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/local"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DriverName is the name of the driver used for the local cache
+	DriverName = local.Name
+
+	cachePrefix      = "cache-"
+	cacheDisabledKey = cachePrefix + "disabled"
+)
+
+var builtInCacheLogOpts = map[string]bool{
+	cachePrefix + "max-file": true,
+	cachePrefix + "max-size": true,
+	cachePrefix + "compress": true,
+	cacheDisabledKey:         true,
+}
+
+// defaultCacheOpts are the options used for the local cache unless they are
+// overridden through the "cache-" prefixed log opts.
+var defaultCacheOpts = map[string]string{
+	"max-file": "2",
+	"max-size": "20m",
+	"compress": "true",
+}
+
+func init() {
+	logger.AddBuiltinLogOpts(builtInCacheLogOpts)
+	logger.RegisterExternalValidator(validateLogCacheOpts)
+}
+
+// WithLocalCache wraps the passed in logger with a logger that also writes
+// every message to a local cache. Reads are served from the cache.
+// info.LogPath must be set to the path of the cache file.
+func WithLocalCache(l logger.Logger, info logger.Info) (logger.Logger, error) {
+	info.Config = cacheOpts(info.Config)
+	cacher, err := local.New(info)
+	if err != nil {
+		return nil, errors.Wrap(err, "error initializing local log cache driver")
+	}
+
+	return &loggerWithCache{
+		l:     l,
+		cache: cacher,
+	}, nil
+}
+
+// ShouldUseCache returns whether a local cache should be set up for a logger
+// with the given log opts.
+func ShouldUseCache(cfg map[string]string) bool {
+	v, ok := cfg[cacheDisabledKey]
+	if !ok {
+		return true
+	}
+	disabled, _ := strconv.ParseBool(v)
+	return !disabled
+}
+
+type loggerWithCache struct {
+	l     logger.Logger
+	cache logger.Logger
+}
+
+func (l *loggerWithCache) Log(msg *logger.Message) error {
+	// copy the message as the original will be reset once the call to `Log` is complete
+	dup := logger.NewMessage()
+	dup.Line = append(dup.Line, msg.Line...)
+	dup.Source = msg.Source
+	dup.Timestamp = msg.Timestamp
+	dup.Partial = msg.Partial
+
+	if err := l.l.Log(msg); err != nil {
+		logger.PutMessage(dup)
+		return err
+	}
+	return errors.Wrap(l.cache.Log(dup), "error writing log message to local cache")
+}
+
+func (l *loggerWithCache) Name() string {
+	return l.l.Name()
+}
+
+func (l *loggerWithCache) ReadLogs(config logger.ReadConfig) *logger.LogWatcher {
+	return l.cache.(logger.LogReader).ReadLogs(config)
+}
+
+func (l *loggerWithCache) Close() error {
+	err := l.l.Close()
+	if err := l.cache.Close(); err != nil {
+		logrus.WithError(err).Warn("error while shutting down local log cache")
+	}
+	return err
+}
+
+// cacheOpts extracts the "cache-" prefixed options from the log opts and
+// returns them with the prefix stripped, filling in defaults for anything
+// that was not set.
+func cacheOpts(cfg map[string]string) map[string]string {
+	opts := make(map[string]string, len(defaultCacheOpts))
+	for k, v := range defaultCacheOpts {
+		opts[k] = v
+	}
+	for k, v := range cfg {
+		if k == cacheDisabledKey || !strings.HasPrefix(k, cachePrefix) {
+			continue
+		}
+		opts[strings.TrimPrefix(k, cachePrefix)] = v
+	}
+	return opts
+}
+
+func validateLogCacheOpts(cfg map[string]string) error {
+	if v, ok := cfg[cacheDisabledKey]; ok {
+		if _, err := strconv.ParseBool(v); err != nil {
+			return errors.Wrapf(err, "invalid value for option %s", cacheDisabledKey)
+		}
+	}
+	return errors.Wrap(local.ValidateLogOpt(cacheOpts(cfg)), "invalid local cache log opts")
+}
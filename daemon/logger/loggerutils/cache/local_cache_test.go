@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/daemon/logger"
+)
+
+type fakeLogger struct {
+	lines []string
+}
+
+func (l *fakeLogger) Log(msg *logger.Message) error {
+	l.lines = append(l.lines, string(msg.Line))
+	logger.PutMessage(msg)
+	return nil
+}
+
+func (l *fakeLogger) Name() string {
+	return "fake"
+}
+
+func (l *fakeLogger) Close() error {
+	return nil
+}
+
+func TestLogWithLocalCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docker-log-cache-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	remote := &fakeLogger{}
+	l, err := WithLocalCache(remote, logger.Info{LogPath: filepath.Join(dir, "container-cached.log")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if l.Name() != "fake" {
+		t.Fatalf("expected name of the wrapped logger, got %q", l.Name())
+	}
+
+	for _, line := range []string{"hello", "world"} {
+		msg := logger.NewMessage()
+		msg.Source = "stdout"
+		msg.Timestamp = time.Now()
+		msg.Line = append(msg.Line, line...)
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(remote.lines) != 2 || remote.lines[0] != "hello" || remote.lines[1] != "world" {
+		t.Fatalf("unexpected lines written to wrapped logger: %v", remote.lines)
+	}
+
+	reader, ok := l.(logger.LogReader)
+	if !ok {
+		t.Fatal("expected cached logger to support reading")
+	}
+	watcher := reader.ReadLogs(logger.ReadConfig{Tail: -1})
+	defer watcher.Close()
+
+	var lines []string
+	for msg := range watcher.Msg {
+		lines = append(lines, string(msg.Line))
+	}
+	if len(lines) != 2 || lines[0] != "hello" || lines[1] != "world" {
+		t.Fatalf("unexpected lines read from cache: %v", lines)
+	}
+}
+
+func TestShouldUseCache(t *testing.T) {
+	if !ShouldUseCache(nil) {
+		t.Fatal("expected cache to be enabled by default")
+	}
+	if ShouldUseCache(map[string]string{cacheDisabledKey: "true"}) {
+		t.Fatal("expected cache to be disabled")
+	}
+}
+
+func TestValidateLogCacheOpts(t *testing.T) {
+	if err := validateLogCacheOpts(map[string]string{"cache-max-size": "1m", "cache-max-file": "3", "other": "ignored"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateLogCacheOpts(map[string]string{"cache-disabled": "maybe"}); err == nil {
+		t.Fatal("expected error for invalid cache-disabled value")
+	}
+	if err := validateLogCacheOpts(map[string]string{"cache-max-file": "0"}); err == nil {
+		t.Fatal("expected error for invalid cache-max-file value")
+	}
+}
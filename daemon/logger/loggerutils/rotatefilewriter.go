@@ -1,6 +1,8 @@
 package loggerutils
 
 import (
+	"compress/gzip"
+	"io"
 	"os"
 	"strconv"
 	"sync"
@@ -15,11 +17,13 @@ type RotateFileWriter struct {
 	capacity     int64 //maximum size of each file
 	currentSize  int64 // current size of the latest file
 	maxFiles     int   //maximum number of files
+	compress     bool  // whether rotated files are gzip compressed
 	notifyRotate *pubsub.Publisher
 }
 
-//NewRotateFileWriter creates new RotateFileWriter
-func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateFileWriter, error) {
+//NewRotateFileWriter creates new RotateFileWriter. If compress is set, rotated
+//files are gzip compressed and get a ".gz" suffix.
+func NewRotateFileWriter(logPath string, capacity int64, maxFiles int, compress bool) (*RotateFileWriter, error) {
 	log, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
 	if err != nil {
 		return nil, err
@@ -35,6 +39,7 @@ func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateF
 		capacity:     capacity,
 		currentSize:  size,
 		maxFiles:     maxFiles,
+		compress:     compress,
 		notifyRotate: pubsub.NewPublisher(0, 1),
 	}, nil
 }
@@ -65,7 +70,7 @@ func (w *RotateFileWriter) checkCapacityAndRotate() error {
 		if err := w.f.Close(); err != nil {
 			return err
 		}
-		if err := rotate(name, w.maxFiles); err != nil {
+		if err := rotate(name, w.maxFiles, w.compress); err != nil {
 			return err
 		}
 		file, err := os.OpenFile(name, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 06400)
@@ -80,24 +85,72 @@ func (w *RotateFileWriter) checkCapacityAndRotate() error {
 	return nil
 }
 
-func rotate(name string, maxFiles int) error {
+func rotate(name string, maxFiles int, compress bool) error {
 	if maxFiles < 2 {
 		return nil
 	}
+
+	var extension string
+	if compress {
+		extension = ".gz"
+	}
 	for i := maxFiles - 1; i > 1; i-- {
-		toPath := name + "." + strconv.Itoa(i)
-		fromPath := name + "." + strconv.Itoa(i-1)
+		toPath := name + "." + strconv.Itoa(i) + extension
+		fromPath := name + "." + strconv.Itoa(i-1) + extension
 		if err := os.Rename(fromPath, toPath); err != nil && !os.IsNotExist(err) {
 			return err
 		}
 	}
 
-	if err := os.Rename(name, name+".1"); err != nil && !os.IsNotExist(err) {
+	if err := os.Rename(name, name+".1"); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
 		return err
 	}
+	if compress {
+		return compressFile(name + ".1")
+	}
 	return nil
 }
 
+// compressFile gzips the file at the given path into path + ".gz" and
+// removes the uncompressed original once the compressed copy is complete.
+func compressFile(fileName string) (retErr error) {
+	src, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmpName := fileName + ".gz.tmp"
+	dst, err := os.OpenFile(tmpName, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0640)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		dst.Close()
+		if retErr != nil {
+			os.Remove(tmpName)
+		}
+	}()
+
+	compressor := gzip.NewWriter(dst)
+	if _, err := io.Copy(compressor, src); err != nil {
+		return err
+	}
+	if err := compressor.Close(); err != nil {
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, fileName+".gz"); err != nil {
+		return err
+	}
+	return os.Remove(fileName)
+}
+
 // LogPath returns the location the given writer logs to.
 func (w *RotateFileWriter) LogPath() string {
 	return w.f.Name()
@@ -108,6 +161,11 @@ func (w *RotateFileWriter) MaxFiles() int {
 	return w.maxFiles
 }
 
+// Compress returns whether rotated files are gzip compressed
+func (w *RotateFileWriter) Compress() bool {
+	return w.compress
+}
+
 //NotifyRotate returns the new subscriber
 func (w *RotateFileWriter) NotifyRotate() chan interface{} {
 	return w.notifyRotate.Subscribe()
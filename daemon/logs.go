@@ -77,8 +77,23 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 		since = time.Unix(s, n)
 	}
 
+	var until time.Time
+	if config.Until != "" && config.Until != "0" {
+		s, n, err := timetypes.ParseTimestamps(config.Until, 0)
+		if err != nil {
+			return nil, err
+		}
+		until = time.Unix(s, n)
+	}
+
+	// there is nothing left to follow if the upper bound is in the past
+	if !until.IsZero() && until.Before(time.Now()) {
+		follow = false
+	}
+
 	readConfig := logger.ReadConfig{
 		Since:  since,
+		Until:  until,
 		Tail:   tailLines,
 		Follow: follow,
 	}
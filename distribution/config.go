@@ -13,6 +13,7 @@ import (
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	refstore "github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
@@ -74,6 +75,23 @@ type ImagePushConfig struct {
 	TrustKey libtrust.PrivateKey
 	// UploadManager dispatches uploads.
 	UploadManager *xfer.LayerUploadManager
+	// LayerCompression is the compression algorithm used for layers that
+	// are pushed. Layers are never pushed uncompressed; anything other than
+	// archive.Zstd results in gzip compressed layers.
+	LayerCompression archive.Compression
+}
+
+// MediaTypeZstdLayer is the media type used for zstd compressed layers in
+// schema2 manifests.
+const MediaTypeZstdLayer = "application/vnd.docker.image.rootfs.diff.tar.zstd"
+
+// layerMediaType returns the media type of a layer that was compressed with
+// the given compression algorithm by compress.
+func layerMediaType(compression archive.Compression) string {
+	if compression == archive.Zstd {
+		return MediaTypeZstdLayer
+	}
+	return schema2.MediaTypeLayer
 }
 
 // ImageConfigStore handles storing and getting image configurations
@@ -9,6 +9,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/registry"
 	"golang.org/x/net/context"
@@ -146,8 +147,9 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 }
 
 // compress returns an io.ReadCloser which will supply a compressed version of
-// the provided Reader. The caller must close the ReadCloser after reading the
-// compressed data.
+// the provided Reader, using the given compression algorithm. Gzip is used
+// unless compression is archive.Zstd. The caller must close the ReadCloser
+// after reading the compressed data.
 //
 // Note that this function returns a reader instead of taking a writer as an
 // argument so that it can be used with httpBlobWriter's ReadFrom method.
@@ -158,16 +160,26 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 // is finished. This allows the caller to make sure the goroutine finishes
 // before it releases any resources connected with the reader that was
 // passed in.
-func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
+func compress(in io.Reader, compression archive.Compression) (io.ReadCloser, chan struct{}) {
 	compressionDone := make(chan struct{})
 
 	pipeReader, pipeWriter := io.Pipe()
 	// Use a bufio.Writer to avoid excessive chunking in HTTP request.
 	bufWriter := bufio.NewWriterSize(pipeWriter, compressionBufSize)
-	compressor := gzip.NewWriter(bufWriter)
 
 	go func() {
-		_, err := io.Copy(compressor, in)
+		var (
+			compressor io.WriteCloser
+			err        error
+		)
+		if compression == archive.Zstd {
+			compressor, err = archive.CompressStream(bufWriter, archive.Zstd)
+		} else {
+			compressor = gzip.NewWriter(bufWriter)
+		}
+		if err == nil {
+			_, err = io.Copy(compressor, in)
+		}
 		if err == nil {
 			err = compressor.Close()
 		}
@@ -0,0 +1,56 @@
+package distribution
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os/exec"
+	"testing"
+
+	"github.com/docker/docker/pkg/archive"
+)
+
+func TestCompress(t *testing.T) {
+	for _, compression := range []archive.Compression{archive.Gzip, archive.Zstd} {
+		if compression == archive.Zstd {
+			if _, err := exec.LookPath("zstd"); err != nil {
+				t.Log("zstd not installed, skipping zstd compression")
+				continue
+			}
+		}
+
+		data := bytes.Repeat([]byte("layer data "), 1024)
+		compressed, compressionDone := compress(bytes.NewReader(data), compression)
+		out, err := ioutil.ReadAll(compressed)
+		compressed.Close()
+		<-compressionDone
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if detected := archive.DetectCompression(out); detected != compression {
+			t.Fatalf("expected %s compression, detected %s", compression.Extension(), detected.Extension())
+		}
+
+		rc, err := archive.DecompressStream(bytes.NewReader(out))
+		if err != nil {
+			t.Fatal(err)
+		}
+		decompressed, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decompressed, data) {
+			t.Fatalf("decompressed %s data does not match original", compression.Extension())
+		}
+	}
+}
+
+func TestLayerMediaType(t *testing.T) {
+	if mt := layerMediaType(archive.Zstd); mt != MediaTypeZstdLayer {
+		t.Fatalf("unexpected media type for zstd layers: %s", mt)
+	}
+	if mt := layerMediaType(archive.Uncompressed); mt != "application/vnd.docker.image.rootfs.diff.tar.gzip" {
+		t.Fatalf("unexpected default media type: %s", mt)
+	}
+}
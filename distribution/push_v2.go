@@ -21,6 +21,7 @@ import (
 	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/stringid"
@@ -144,6 +145,7 @@ func (p *v2Pusher) pushV2Tag(ctx context.Context, ref reference.NamedTagged, id
 		endpoint:          p.endpoint,
 		repo:              p.repo,
 		pushState:         &p.pushState,
+		layerCompression:  p.config.LayerCompression,
 	}
 
 	// Loop bounds condition is to avoid pushing the base layer on Windows.
@@ -244,6 +246,7 @@ type v2PushDescriptor struct {
 	repo              distribution.Repository
 	pushState         *pushState
 	remoteDescriptor  distribution.Descriptor
+	layerCompression  archive.Compression
 	// a set of digests whose presence has been checked in a target repository
 	checkedDigests map[digest.Digest]struct{}
 }
@@ -340,7 +343,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 		case distribution.ErrBlobMounted:
 			progress.Updatef(progressOutput, pd.ID(), "Mounted from %s", err.From.Name())
 
-			err.Descriptor.MediaType = schema2.MediaTypeLayer
+			err.Descriptor.MediaType = metadataMediaType(mountCandidate)
 
 			pd.pushState.Lock()
 			pd.pushState.confirmedV2 = true
@@ -351,6 +354,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 			if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 				Digest:           err.Descriptor.Digest,
 				SourceRepository: pd.repoInfo.Name(),
+				MediaType:        mountCandidate.MediaType,
 			}); err != nil {
 				return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 			}
@@ -428,14 +432,16 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 
 	reader = progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, contentReader), progressOutput, size, pd.ID(), "Pushing")
 
+	mediaType := schema2.MediaTypeLayer
 	switch m := pd.layer.MediaType(); m {
 	case schema2.MediaTypeUncompressedLayer:
-		compressedReader, compressionDone := compress(reader)
+		compressedReader, compressionDone := compress(reader, pd.layerCompression)
 		defer func(closer io.Closer) {
 			closer.Close()
 			<-compressionDone
 		}(reader)
 		reader = compressedReader
+		mediaType = layerMediaType(pd.layerCompression)
 	case schema2.MediaTypeLayer:
 	default:
 		reader.Close()
@@ -463,13 +469,14 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 	if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 		Digest:           pushDigest,
 		SourceRepository: pd.repoInfo.Name(),
+		MediaType:        mediaType,
 	}); err != nil {
 		return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 	}
 
 	desc := distribution.Descriptor{
 		Digest:    pushDigest,
-		MediaType: schema2.MediaTypeLayer,
+		MediaType: mediaType,
 		Size:      nn,
 	}
 
@@ -538,11 +545,12 @@ attempts:
 				if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 					Digest:           desc.Digest,
 					SourceRepository: pd.repoInfo.Name(),
+					MediaType:        meta.MediaType,
 				}); err != nil {
 					return distribution.Descriptor{}, false, xfer.DoNotRetry{Err: err}
 				}
 			}
-			desc.MediaType = schema2.MediaTypeLayer
+			desc.MediaType = metadataMediaType(*meta)
 			exists = true
 			break attempts
 		case distribution.ErrBlobUnknown:
@@ -596,6 +604,16 @@ func getMaxMountAndExistenceCheckAttempts(layer PushLayer) (maxMountAttempts, ma
 // getRepositoryMountCandidates returns an array of v2 metadata items belonging to the given registry. The
 // array is sorted from youngest to oldest. If requireRegistryMatch is true, the resulting array will contain
 // only metadata entries having registry part of SourceRepository matching the part of repoInfo.
+// metadataMediaType returns the media type of the blob described by meta.
+// Metadata recorded before media types were tracked describes gzip
+// compressed layers.
+func metadataMediaType(meta metadata.V2Metadata) string {
+	if meta.MediaType != "" {
+		return meta.MediaType
+	}
+	return schema2.MediaTypeLayer
+}
+
 func getRepositoryMountCandidates(
 	repoInfo reference.Named,
 	hmacKey []byte,
@@ -23,6 +23,7 @@ import (
 	"github.com/docker/docker/pkg/symlink"
 	"github.com/docker/docker/pkg/system"
 	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool) error {
@@ -41,7 +42,8 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	if err := chrootarchive.Untar(inTar, tmpDir, nil); err != nil {
 		return err
 	}
-	// read manifest, if no file then load in legacy mode
+	// read manifest, if no file then load as an OCI image layout or in
+	// legacy mode
 	manifestPath, err := safePath(tmpDir, manifestFileName)
 	if err != nil {
 		return err
@@ -49,6 +51,13 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	manifestFile, err := os.Open(manifestPath)
 	if err != nil {
 		if os.IsNotExist(err) {
+			layoutPath, err := safePath(tmpDir, ocispec.ImageLayoutFile)
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(layoutPath); err == nil {
+				return l.ociLoad(tmpDir, outStream, progressOutput)
+			}
 			return l.legacyLoad(tmpDir, outStream, progressOutput)
 		}
 		return err
@@ -349,6 +358,170 @@ func (l *tarexporter) legacyLoadImage(oldID, sourceDir string, loadedMap map[str
 	return nil
 }
 
+func (l *tarexporter) ociLoad(tmpDir string, outStream io.Writer, progressOutput progress.Output) error {
+	var layout ocispec.ImageLayout
+	if err := readOCIFile(tmpDir, ocispec.ImageLayoutFile, &layout); err != nil {
+		return err
+	}
+	if layout.Version != ocispec.ImageLayoutVersion {
+		return fmt.Errorf("unsupported OCI image layout version: %q", layout.Version)
+	}
+
+	var index ocispec.Index
+	if err := readOCIFile(tmpDir, ociIndexFileName, &index); err != nil {
+		return err
+	}
+
+	loadedMap := make(map[digest.Digest]image.ID)
+	var imageIDsStr string
+	var imageRefCount int
+
+	for _, desc := range index.Manifests {
+		imgID, loaded := loadedMap[desc.Digest]
+		if !loaded {
+			var err error
+			imgID, err = l.ociLoadImage(tmpDir, desc, progressOutput)
+			if err != nil {
+				return err
+			}
+			loadedMap[desc.Digest] = imgID
+			imageIDsStr += fmt.Sprintf("Loaded image ID: %s\n", imgID)
+			l.loggerImgEvent.LogImageEvent(imgID.String(), imgID.String(), "load")
+		}
+
+		ref, err := ociReference(desc)
+		if err != nil {
+			return err
+		}
+		if ref == nil {
+			continue
+		}
+		l.setLoadedTag(ref, imgID.Digest(), outStream)
+		outStream.Write([]byte(fmt.Sprintf("Loaded image: %s\n", reference.FamiliarString(ref))))
+		imageRefCount++
+	}
+
+	if imageRefCount == 0 {
+		outStream.Write([]byte(imageIDsStr))
+	}
+
+	return nil
+}
+
+func (l *tarexporter) ociLoadImage(tmpDir string, desc ocispec.Descriptor, progressOutput progress.Output) (image.ID, error) {
+	if desc.MediaType != ocispec.MediaTypeImageManifest {
+		return "", fmt.Errorf("unsupported media type in OCI image index: %q", desc.MediaType)
+	}
+
+	manifestJSON, err := readOCIBlob(tmpDir, desc.Digest)
+	if err != nil {
+		return "", err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return "", err
+	}
+
+	config, err := readOCIBlob(tmpDir, manifest.Config.Digest)
+	if err != nil {
+		return "", err
+	}
+	img, err := image.NewFromJSON(config)
+	if err != nil {
+		return "", err
+	}
+	rootFS := *img.RootFS
+	rootFS.DiffIDs = nil
+
+	if expected, actual := len(manifest.Layers), len(img.RootFS.DiffIDs); expected != actual {
+		return "", fmt.Errorf("invalid manifest, layers length mismatch: expected %d, got %d", expected, actual)
+	}
+
+	for i, diffID := range img.RootFS.DiffIDs {
+		layerPath, err := ociBlobPath(tmpDir, manifest.Layers[i].Digest)
+		if err != nil {
+			return "", err
+		}
+		r := rootFS
+		r.Append(diffID)
+		newLayer, err := l.ls.Get(r.ChainID())
+		if err != nil {
+			newLayer, err = l.loadLayer(layerPath, rootFS, diffID.String(), distribution.Descriptor{}, progressOutput)
+			if err != nil {
+				return "", err
+			}
+		}
+		defer layer.ReleaseAndLog(l.ls, newLayer)
+		if expected, actual := diffID, newLayer.DiffID(); expected != actual {
+			return "", fmt.Errorf("invalid diffID for layer %d: expected %q, got %q", i, expected, actual)
+		}
+		rootFS.Append(diffID)
+	}
+
+	return l.is.Create(config)
+}
+
+// ociReference returns the reference an image in an OCI image index was
+// saved with, or nil if it has none. Tools other than docker may only
+// record the tag, without the repository, in which case nil is returned
+// as well.
+func ociReference(desc ocispec.Descriptor) (reference.NamedTagged, error) {
+	if name, ok := desc.Annotations[ociImageNameAnnotation]; ok {
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			return nil, err
+		}
+		ref, ok := named.(reference.NamedTagged)
+		if !ok {
+			return nil, fmt.Errorf("invalid tag %q", name)
+		}
+		return ref, nil
+	}
+	named, err := reference.ParseNormalizedNamed(desc.Annotations[ociRefNameAnnotation])
+	if err != nil {
+		return nil, nil
+	}
+	ref, _ := named.(reference.NamedTagged)
+	return ref, nil
+}
+
+func readOCIFile(dir, name string, v interface{}) error {
+	p, err := safePath(dir, name)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(v)
+}
+
+// readOCIBlob reads a blob from an OCI image layout and verifies that its
+// content matches the digest.
+func readOCIBlob(dir string, dgst digest.Digest) ([]byte, error) {
+	p, err := ociBlobPath(dir, dgst)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	if dgst.Algorithm().FromBytes(data) != dgst {
+		return nil, fmt.Errorf("invalid content for blob %s", dgst)
+	}
+	return data, nil
+}
+
+func ociBlobPath(dir string, dgst digest.Digest) (string, error) {
+	if err := dgst.Validate(); err != nil {
+		return "", err
+	}
+	return safePath(dir, filepath.Join(ociBlobsDir, dgst.Algorithm().String(), dgst.Hex()))
+}
+
 func safePath(base, path string) (string, error) {
 	return symlink.FollowSymlinkInScope(filepath.Join(base, path), base)
 }
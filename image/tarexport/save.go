@@ -17,6 +17,8 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/system"
 	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
 
@@ -31,6 +33,7 @@ type saveSession struct {
 	images      map[image.ID]*imageDescriptor
 	savedLayers map[string]struct{}
 	diffIDPaths map[layer.DiffID]string // cache every diffID blob to avoid duplicates
+	ociLayers   map[layer.DiffID]ocispec.Descriptor // layers already written to the OCI layout
 }
 
 func (l *tarexporter) Save(names []string, outStream io.Writer) error {
@@ -42,6 +45,17 @@ func (l *tarexporter) Save(names []string, outStream io.Writer) error {
 	return (&saveSession{tarexporter: l, images: images}).save(outStream)
 }
 
+// SaveOCI is like Save, but writes the images as an OCI image layout
+// instead of the docker format.
+func (l *tarexporter) SaveOCI(names []string, outStream io.Writer) error {
+	images, err := l.parseNames(names)
+	if err != nil {
+		return err
+	}
+
+	return (&saveSession{tarexporter: l, images: images}).saveOCI(outStream)
+}
+
 func (l *tarexporter) parseNames(names []string) (map[image.ID]*imageDescriptor, error) {
 	imgDescr := make(map[image.ID]*imageDescriptor)
 
@@ -360,3 +374,193 @@ func (s *saveSession) saveLayer(id layer.ChainID, legacyImg image.V1Image, creat
 	}
 	return src, nil
 }
+
+func (s *saveSession) saveOCI(outStream io.Writer) error {
+	s.ociLayers = make(map[layer.DiffID]ocispec.Descriptor)
+
+	tempDir, err := ioutil.TempDir("", "docker-export-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	s.outDir = tempDir
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+	}
+
+	for id, imageDescr := range s.images {
+		desc, err := s.saveOCIImage(id)
+		if err != nil {
+			return err
+		}
+
+		if len(imageDescr.refs) == 0 {
+			index.Manifests = append(index.Manifests, desc)
+		}
+		for _, ref := range imageDescr.refs {
+			refDesc := desc
+			refDesc.Annotations = map[string]string{
+				ociImageNameAnnotation: ref.String(),
+				ociRefNameAnnotation:   ref.Tag(),
+			}
+			index.Manifests = append(index.Manifests, refDesc)
+		}
+		s.tarexporter.loggerImgEvent.LogImageEvent(id.String(), id.String(), "save")
+	}
+
+	if err := writeOCIFile(filepath.Join(tempDir, ocispec.ImageLayoutFile), ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion}); err != nil {
+		return err
+	}
+	if err := writeOCIFile(filepath.Join(tempDir, ociIndexFileName), index); err != nil {
+		return err
+	}
+
+	fs, err := archive.Tar(tempDir, archive.Uncompressed)
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	_, err = io.Copy(outStream, fs)
+	return err
+}
+
+// writeOCIFile writes v as JSON to the given path, with a fixed timestamp
+// so that saving the same images twice results in the same archive.
+func writeOCIFile(path string, v interface{}) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewEncoder(f).Encode(v); err != nil {
+		f.Close()
+		return err
+	}
+
+	f.Close()
+
+	return system.Chtimes(path, time.Unix(0, 0), time.Unix(0, 0))
+}
+
+// saveOCIImage writes the config, layers and manifest of an image to the
+// OCI layout and returns the descriptor of the manifest.
+func (s *saveSession) saveOCIImage(id image.ID) (ocispec.Descriptor, error) {
+	img, err := s.is.Get(id)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	if len(img.RootFS.DiffIDs) == 0 {
+		return ocispec.Descriptor{}, fmt.Errorf("empty export - not implemented")
+	}
+
+	configDesc, err := s.writeOCIBlob(ocispec.MediaTypeImageConfig, img.RawJSON())
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	manifest := ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    configDesc,
+	}
+	for i := range img.RootFS.DiffIDs {
+		rootFS := *img.RootFS
+		rootFS.DiffIDs = rootFS.DiffIDs[:i+1]
+
+		layerDesc, err := s.saveOCILayer(rootFS.ChainID())
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		manifest.Layers = append(manifest.Layers, layerDesc)
+	}
+
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc, err := s.writeOCIBlob(ocispec.MediaTypeImageManifest, manifestJSON)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if img.OS != "" {
+		desc.Platform = &ocispec.Platform{
+			Architecture: img.Architecture,
+			OS:           img.OS,
+		}
+	}
+	return desc, nil
+}
+
+// saveOCILayer writes the uncompressed tar stream of a layer to the OCI
+// layout. The layer is stored under its DiffID, which is the digest of the
+// uncompressed stream.
+func (s *saveSession) saveOCILayer(id layer.ChainID) (ocispec.Descriptor, error) {
+	l, err := s.ls.Get(id)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer layer.ReleaseAndLog(s.ls, l)
+
+	if desc, exists := s.ociLayers[l.DiffID()]; exists {
+		return desc, nil
+	}
+
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digest.Digest(l.DiffID()),
+	}
+	blobPath, err := s.ociBlobPath(desc.Digest)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	// Use system.CreateSequential rather than os.Create. This ensures sequential
+	// file access on Windows to avoid eating into MM standby list.
+	// On Linux, this equates to a regular os.Create.
+	tarFile, err := system.CreateSequential(blobPath)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer tarFile.Close()
+
+	arch, err := l.TarStream()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer arch.Close()
+
+	if desc.Size, err = io.Copy(tarFile, arch); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	s.ociLayers[l.DiffID()] = desc
+	return desc, nil
+}
+
+func (s *saveSession) writeOCIBlob(mediaType string, data []byte) (ocispec.Descriptor, error) {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	blobPath, err := s.ociBlobPath(desc.Digest)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if err := ioutil.WriteFile(blobPath, data, 0644); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// ociBlobPath returns the path of the blob with the given digest in the OCI
+// layout, creating its parent directory if needed.
+func (s *saveSession) ociBlobPath(dgst digest.Digest) (string, error) {
+	dir := filepath.Join(s.outDir, ociBlobsDir, dgst.Algorithm().String())
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, dgst.Hex()), nil
+}
@@ -13,6 +13,15 @@ const (
 	legacyConfigFileName       = "json"
 	legacyVersionFileName      = "VERSION"
 	legacyRepositoriesFileName = "repositories"
+
+	ociIndexFileName = "index.json"
+	ociBlobsDir      = "blobs"
+
+	// ociRefNameAnnotation holds the tag of an image in an OCI image layout.
+	ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+	// ociImageNameAnnotation holds the full reference of an image in an OCI
+	// image layout, as used by containerd.
+	ociImageNameAnnotation = "io.containerd.image.name"
 )
 
 type manifestItem struct {
@@ -0,0 +1,163 @@
+package tarexport
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/daemon/graphdriver"
+	_ "github.com/docker/docker/daemon/graphdriver/vfs"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/idtools"
+	refstore "github.com/docker/docker/reference"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type nopImageEventLogger struct{}
+
+func (nopImageEventLogger) LogImageEvent(imageID, refName, action string) {}
+
+type testStores struct {
+	is image.Store
+	ls layer.Store
+	rs refstore.Store
+}
+
+func newTestStores(t *testing.T, root string) testStores {
+	uidMap := []idtools.IDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}}
+	gidMap := []idtools.IDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}}
+	driver, err := graphdriver.GetDriver("vfs", nil, graphdriver.Options{Root: filepath.Join(root, "vfs"), UIDMaps: uidMap, GIDMaps: gidMap})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fms, err := layer.NewFSMetadataStore(filepath.Join(root, "layerdb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls, err := layer.NewStoreFromGraphDriver(fms, driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ifs, err := image.NewFSStoreBackend(filepath.Join(root, "imagedb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	is, err := image.NewImageStore(ifs, ls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := refstore.NewReferenceStore(filepath.Join(root, "repositories.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testStores{is: is, ls: ls, rs: rs}
+}
+
+func layerTar(t *testing.T, name, content string) []byte {
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSaveLoadOCI(t *testing.T) {
+	root, err := ioutil.TempDir("", "tarexport-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	src := newTestStores(t, filepath.Join(root, "src"))
+	l, err := src.ls.Register(bytes.NewReader(layerTar(t, "hello", "world")), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer layer.ReleaseAndLog(src.ls, l)
+
+	rootFS := image.NewRootFS()
+	rootFS.Append(l.DiffID())
+	config, err := json.Marshal(&image.Image{
+		V1Image: image.V1Image{OS: "linux", Architecture: "amd64"},
+		RootFS:  rootFS,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	imgID, err := src.is.Create(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	named, err := reference.ParseNormalizedNamed("example/oci:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.rs.AddTag(named, imgID.Digest(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := NewTarExporter(src.is, src.ls, src.rs, nopImageEventLogger{}).SaveOCI([]string{"example/oci:latest"}, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(bytes.NewReader(buf.Bytes()))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = data
+	}
+	if _, ok := files[manifestFileName]; ok {
+		t.Fatal("expected no docker manifest in OCI image layout")
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(files[ociIndexFileName], &index); err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Manifests) != 1 || index.Manifests[0].Annotations[ociRefNameAnnotation] != "latest" {
+		t.Fatalf("unexpected index: %+v", index)
+	}
+	if _, ok := files[filepath.Join(ociBlobsDir, "sha256", imgID.Digest().Hex())]; !ok {
+		t.Fatal("expected image config in OCI image layout")
+	}
+
+	dst := newTestStores(t, filepath.Join(root, "dst"))
+	out := new(bytes.Buffer)
+	if err := NewTarExporter(dst.is, dst.ls, dst.rs, nopImageEventLogger{}).Load(ioutil.NopCloser(buf), out, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dst.is.Get(imgID); err != nil {
+		t.Fatalf("expected image to be loaded: %v", err)
+	}
+	id, err := dst.rs.Get(named)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != imgID.Digest() {
+		t.Fatalf("expected tag to reference %s, got %s", imgID, id)
+	}
+}
@@ -95,6 +95,8 @@ const (
 	Gzip
 	// Xz is xz compression algorithm.
 	Xz
+	// Zstd is zstd compression algorithm.
+	Zstd
 )
 
 const (
@@ -140,6 +142,7 @@ func DetectCompression(source []byte) Compression {
 		Bzip2: {0x42, 0x5A, 0x68},
 		Gzip:  {0x1F, 0x8B, 0x08},
 		Xz:    {0xFD, 0x37, 0x7A, 0x58, 0x5A, 0x00},
+		Zstd:  {0x28, 0xB5, 0x2F, 0xFD},
 	} {
 		if len(source) < len(m) {
 			logrus.Debug("Len too short")
@@ -158,6 +161,18 @@ func xzDecompress(archive io.Reader) (io.ReadCloser, <-chan struct{}, error) {
 	return cmdStream(exec.Command(args[0], args[1:]...), archive)
 }
 
+func zstdDecompress(archive io.Reader) (io.ReadCloser, <-chan struct{}, error) {
+	args := []string{"zstd", "-d", "-c", "-q"}
+
+	return cmdStream(exec.Command(args[0], args[1:]...), archive)
+}
+
+func zstdCompress(dest io.Writer) (io.WriteCloser, error) {
+	args := []string{"zstd", "-c", "-q"}
+
+	return cmdWriteStream(exec.Command(args[0], args[1:]...), dest)
+}
+
 // DecompressStream decompresses the archive and returns a ReaderCloser with the decompressed archive.
 func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 	p := pools.BufioReader32KPool
@@ -199,6 +214,16 @@ func DecompressStream(archive io.Reader) (io.ReadCloser, error) {
 			<-chdone
 			return readBufWrapper.Close()
 		}), nil
+	case Zstd:
+		zstdReader, chdone, err := zstdDecompress(buf)
+		if err != nil {
+			return nil, err
+		}
+		readBufWrapper := p.NewReadCloserWrapper(buf, zstdReader)
+		return ioutils.NewReadCloserWrapper(readBufWrapper, func() error {
+			<-chdone
+			return readBufWrapper.Close()
+		}), nil
 	default:
 		return nil, fmt.Errorf("Unsupported compression format %s", (&compression).Extension())
 	}
@@ -216,6 +241,20 @@ func CompressStream(dest io.Writer, compression Compression) (io.WriteCloser, er
 		gzWriter := gzip.NewWriter(dest)
 		writeBufWrapper := p.NewWriteCloserWrapper(buf, gzWriter)
 		return writeBufWrapper, nil
+	case Zstd:
+		zstdWriter, err := zstdCompress(buf)
+		if err != nil {
+			return nil, err
+		}
+		return ioutils.NewWriteCloserWrapper(zstdWriter, func() error {
+			// wait for the compressor to exit before flushing its output
+			err := zstdWriter.Close()
+			if flushErr := buf.Flush(); err == nil {
+				err = flushErr
+			}
+			p.Put(buf)
+			return err
+		}), nil
 	case Bzip2, Xz:
 		// archive/bzip2 does not support writing, and there is no xz support at all
 		// However, this is not a problem as docker only currently generates gzipped tars
@@ -323,6 +362,8 @@ func (compression *Compression) Extension() string {
 		return "tar.gz"
 	case Xz:
 		return "tar.xz"
+	case Zstd:
+		return "tar.zst"
 	}
 	return ""
 }
@@ -1217,6 +1258,34 @@ func cmdStream(cmd *exec.Cmd, input io.Reader) (io.ReadCloser, <-chan struct{},
 	return pipeR, chdone, nil
 }
 
+// cmdWriteStream executes a command, and returns a WriteCloser that feeds
+// the command's stdin. The command's stdout is written to output. Closing
+// the returned WriteCloser waits for the command to exit.
+func cmdWriteStream(cmd *exec.Cmd, output io.Writer) (io.WriteCloser, error) {
+	cmd.Stdout = output
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return ioutils.NewWriteCloserWrapper(stdin, func() error {
+		if err := stdin.Close(); err != nil {
+			return err
+		}
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("%s: %s", err, errBuf.String())
+		}
+		return nil
+	}), nil
+}
+
 // NewTempArchive reads the content of src into a temporary file, and returns the contents
 // of that file as an archive. The archive can only be read once - as soon as reading completes,
 // the file will be deleted.